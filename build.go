@@ -0,0 +1,15 @@
+package vss
+
+// Build builds the site once. force bypasses the incremental build
+// cache, re-rendering every page and re-copying every static file
+// regardless of whether they have changed. This is the
+// implementation behind `vss build`.
+func Build(force bool) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	builder := NewBuilder(config)
+	builder.SetForce(force)
+	return builder.Run()
+}
@@ -12,23 +12,43 @@ import (
 	"sync"
 
 	"github.com/adrg/frontmatter"
-	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
 	"github.com/cbroglie/mustache"
 	"github.com/yuin/goldmark"
-	highlighting "github.com/yuin/goldmark-highlighting/v2"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/renderer/html"
+
+	"github.com/vssio/go-vss/internal/highlight"
+	"github.com/vssio/go-vss/internal/module"
 )
 
+// contentFile pairs a markdown file's location on disk with the path it is
+// addressed by relative to its content root. The two differ only for
+// markdown mounted in from a module's content mount; for the project's own
+// content they are the same. lang and slug are filled in by
+// Builder.assignLanguages before rendering.
+type contentFile struct {
+	path    string
+	relPath string
+	lang    string
+	slug    string
+}
+
 // Builder is a struct for building a static site.
 type Builder struct {
 	config *Config
 
 	// init in Run()
 	templateMap       map[string]*mustache.Template
+	templateSource    map[string][]byte // parallel to templateMap, for cache hashing
 	gm                goldmark.Markdown
 	baseRenderContext map[string]interface{}
+	modules           []module.Module
+	// translations maps a content slug to its rendered path in every
+	// language it exists in, filled in by assignLanguages before rendering.
+	translations map[string]map[string]string
+	// force bypasses the incremental build cache when set; see SetForce.
+	force bool
 }
 
 // NewBuilder returns a new Builder.
@@ -43,6 +63,11 @@ func (b Builder) GetDistPath() string {
 	return b.config.Dist
 }
 
+// GetConfig returns the builder's config.
+func (b Builder) GetConfig() *Config {
+	return b.config
+}
+
 // ReloadConfig reloads the config file.
 func (b *Builder) ReloadConfig() error {
 	c, err := LoadConfig()
@@ -58,50 +83,91 @@ func (b *Builder) SetBaseUrl(baseURL string) {
 	b.config.BaseUrl = baseURL
 }
 
-// Run builds the static site.
-func (b Builder) Run() error {
-	if err := createDistDir(b.config.Dist, true); err != nil {
+// SetForce sets whether Run bypasses the incremental build cache,
+// re-rendering every page and re-copying every static file regardless of
+// whether they have changed.
+func (b *Builder) SetForce(force bool) {
+	b.force = force
+}
+
+// Clean removes the dist directory and the incremental build cache, so
+// the next Run starts from scratch.
+func (b Builder) Clean() error {
+	if err := os.RemoveAll(b.config.Dist); err != nil {
+		return err
+	}
+	return os.RemoveAll(cacheDir)
+}
+
+// Run builds the static site. Unless SetForce was used, content files
+// whose hashes match the incremental build cache are not re-rendered, and
+// static files whose size and modification time match their copy in dist
+// are not re-copied.
+func (b *Builder) Run() error {
+	if err := createDistDir(b.config.Dist, b.force); err != nil {
+		return err
+	}
+
+	modules, err := b.resolveModules()
+	if err != nil {
 		return err
 	}
+	b.modules = modules
 
-	log.Printf("[INFO] copying static files from %s to %s\n", b.config.Static, b.config.Dist)
-	if err := copyStatic(b.config.Static, b.config.Dist); err != nil {
+	staticSources := module.Overlay(b.config.Static, modules, module.MountStatic)
+	log.Printf("[INFO] copying static files to %s\n", b.config.Dist)
+	if err := copyStatic(staticSources, b.config.Dist); err != nil {
 		return err
 	}
 
-	markdownFiles, err := getFilePathsByExt(".", ".md")
+	contentSources := module.Overlay(".", modules, module.MountContent)
+	contentFiles, err := b.findContentFiles(contentSources)
 	if err != nil {
 		return err
 	}
-	markdownFiles = b.purgeIgnoreFiles(markdownFiles)
-	log.Printf("[INFO] found %d markdown files\n", len(markdownFiles))
+	log.Printf("[INFO] found %d markdown files\n", len(contentFiles))
 
-	templateFiles, err := getFilePathsByExt(b.config.Layouts, ".html")
+	contentFiles, translations, err := b.assignLanguages(contentFiles)
 	if err != nil {
 		return err
 	}
-	if err := b.initTemplateMap(templateFiles); err != nil {
+	b.translations = translations
+
+	layoutSources := module.Overlay(b.config.Layouts, modules, module.MountLayouts)
+	if err := b.prepare(layoutSources); err != nil {
 		return err
 	}
 
+	manifest := make(map[string]cacheEntry)
+	if !b.force {
+		manifest = loadManifest()
+	}
+	var manifestMu sync.Mutex
+
 	log.Printf("[INFO] rendering markdown files\n")
-	b.gm = b.initGoldmark()
-	// for storing rendered html
-	b.baseRenderContext = b.config.AsMap()
 	// Create a channel to receive errors from goroutines
 	errCh := make(chan error)
 
 	// Use a wait group to wait for all goroutines to finish
 	var wg sync.WaitGroup
-	wg.Add(len(markdownFiles))
-	for _, markdownPath := range markdownFiles {
-		go func(path string) {
-			log.Printf("[INFO] rendering %s\n", path)
-			if err := b.renderContent(path); err != nil {
+	wg.Add(len(contentFiles))
+	for _, cf := range contentFiles {
+		go func(cf contentFile) {
+			defer wg.Done()
+			entry, cached, err := b.renderCached(cf, manifest, &manifestMu)
+			if err != nil {
 				errCh <- err
+				return
+			}
+			if cached {
+				log.Printf("[INFO] skipping %s (cached)\n", cf.relPath)
+			} else {
+				log.Printf("[INFO] rendering %s\n", cf.relPath)
 			}
-			wg.Done()
-		}(markdownPath)
+			manifestMu.Lock()
+			manifest[cf.relPath] = entry
+			manifestMu.Unlock()
+		}(cf)
 	}
 
 	// Start a goroutine to close the error channel once all goroutines are done
@@ -116,72 +182,236 @@ func (b Builder) Run() error {
 			return err
 		}
 	}
+	return saveManifest(manifest)
+}
+
+// renderCached renders cf and writes it to dist, unless the cache
+// manifest shows its input and template hashes already match an output
+// that is still on disk. It returns the entry to record for cf (unchanged
+// on a cache hit) and whether it was a hit.
+func (b Builder) renderCached(cf contentFile, manifest map[string]cacheEntry, mu *sync.Mutex) (cacheEntry, bool, error) {
+	content, err := os.ReadFile(cf.path)
+	if err != nil {
+		return cacheEntry{}, false, err
+	}
+	inputHash := hashBytes(content)
+
+	htmlPath := b.langHtmlPath(cf.lang, cf.slug)
+	templatePath := convertMarkdownPathToHtmlPath(cf.slug + ".md")
+	_, templateKey, err := b.lookUpTemplate(templatePath, cf.lang)
+	if err != nil {
+		return cacheEntry{}, false, err
+	}
+
+	var yfm YamlFrontMatter
+	if _, err := frontmatter.Parse(bytes.NewReader(content), &yfm); err != nil {
+		return cacheEntry{}, false, err
+	}
+	tmplHash, err := templateHash(b.templateSource[templateKey], yfm.AsMap(), b.baseRenderContext)
+	if err != nil {
+		return cacheEntry{}, false, err
+	}
+
+	mu.Lock()
+	entry, ok := manifest[cf.relPath]
+	mu.Unlock()
+
+	distPath := filepath.Join(b.config.Dist, htmlPath)
+	if ok && entry.InputHash == inputHash && entry.TemplateHash == tmplHash && entry.OutputPath == htmlPath {
+		if _, err := os.Stat(distPath); err == nil {
+			return entry, true, nil
+		}
+	}
+
+	renderedPath, rendered, err := b.renderContent(cf)
+	if err != nil {
+		return cacheEntry{}, false, err
+	}
+	if err := b.writeDist(renderedPath, rendered); err != nil {
+		return cacheEntry{}, false, err
+	}
+
+	return cacheEntry{
+		InputHash:    inputHash,
+		TemplateHash: tmplHash,
+		OutputPath:   renderedPath,
+		OutputHash:   hashBytes(rendered),
+	}, false, nil
+}
+
+// writeDist writes data to dist at htmlPath, creating parent directories
+// as needed.
+func (b Builder) writeDist(htmlPath string, data []byte) error {
+	distPath := filepath.Join(b.config.Dist, htmlPath)
+	if err := os.MkdirAll(filepath.Dir(distPath), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(distPath, data, 0o644)
+}
+
+// resolveModules resolves the site's imported modules, if any, fetching
+// them into the module cache as needed.
+func (b Builder) resolveModules() ([]module.Module, error) {
+	if b.config.Module == nil || len(b.config.Module.Imports) == 0 {
+		return nil, nil
+	}
+	return module.Resolve(b.config.Module.Imports)
+}
+
+// findContentFiles returns every non-ignored markdown file visible through
+// sources (the project's own content plus any module content mounts),
+// keyed by path relative to its source root so the project's own file wins
+// on collision.
+func (b Builder) findContentFiles(sources []module.FileSource) ([]contentFile, error) {
+	byRelPath := make(map[string]contentFile)
+	// project (sources[0]) must be applied last so it wins on collision
+	for i := len(sources) - 1; i >= 0; i-- {
+		src := sources[i]
+		if !existDir(src.Root) {
+			continue
+		}
+		files, err := getFilePathsByExt(src.Root, ".md")
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			rel, err := filepath.Rel(src.Root, f)
+			if err != nil {
+				return nil, err
+			}
+			rel = filepath.ToSlash(rel)
+			byRelPath[rel] = contentFile{path: f, relPath: rel}
+		}
+	}
+
+	files := make([]contentFile, 0, len(byRelPath))
+	for _, cf := range byRelPath {
+		files = append(files, cf)
+	}
+	return b.purgeIgnoreFiles(files), nil
+}
+
+// prepare initializes the template map, goldmark instance and base render
+// context shared by every render. It must be called once before RenderFile,
+// and Run calls it itself.
+func (b *Builder) prepare(layoutSources []module.FileSource) error {
+	if err := b.initTemplateMap(layoutSources); err != nil {
+		return err
+	}
+	b.gm = b.initGoldmark()
+	b.baseRenderContext = b.config.AsMap()
 	return nil
 }
 
-func (b Builder) purgeIgnoreFiles(files []string) []string {
-	var res []string
-	for _, path := range files {
+// RenderFile renders a single markdown file to dist. prepare must have run
+// first (Run does this automatically); Serve's Watch callback uses it
+// for incremental, single-file rebuilds of the project's own content. Its
+// translations are whatever Run last indexed; a page added or renamed
+// since the last full build won't have fresh siblings until the next one.
+func (b Builder) RenderFile(markdownPath string) error {
+	content, err := os.ReadFile(markdownPath)
+	if err != nil {
+		return err
+	}
+	var yfm YamlFrontMatter
+	if _, err := frontmatter.Parse(strings.NewReader(string(content)), &yfm); err != nil {
+		return err
+	}
+	lang, slug := contentLang(markdownPath, yfm.Lang, b.config)
+	cf := contentFile{path: markdownPath, relPath: markdownPath, lang: lang, slug: slug}
+	htmlPath, rendered, err := b.renderContent(cf)
+	if err != nil {
+		return err
+	}
+	return b.writeDist(htmlPath, rendered)
+}
+
+func (b Builder) purgeIgnoreFiles(files []contentFile) []contentFile {
+	var res []contentFile
+	for _, f := range files {
 		// path からファイル名を取得
-		name := filepath.Base(path)
+		name := filepath.Base(f.relPath)
 		// name が IgnoreFiles に含まれているかを確認し、含まれている場合は削除
 		if slices.Contains(b.config.Build.IgnoreFiles, name) {
 			continue
 		}
-		res = append(res, path)
+		res = append(res, f)
 	}
 	return res
 }
 
-// renderContent renders the markdown file and writes the result to the dist directory.
-func (b Builder) renderContent(markdownPath string) error {
-	htmlPath := convertMarkdownPathToHtmlPath(markdownPath)
-	distFile, err := createDistFile(filepath.Join(b.config.Dist, htmlPath))
+// isPageBundle reports whether markdownPath is the index file of a Hugo
+// style page bundle: a directory holding the markdown file alongside its
+// own resources (images, etc.) that should be copied next to it in dist.
+func isPageBundle(markdownPath string) bool {
+	return filepath.Base(markdownPath) == "index.md"
+}
+
+// renderContent renders cf's markdown file and returns its HTML output
+// and the dist-relative path it belongs at. It does not write dist or
+// touch the cache manifest itself, so the caller can hash the output
+// before deciding whether (and where) to write it.
+func (b Builder) renderContent(cf contentFile) (htmlPath string, rendered []byte, err error) {
+	htmlPath = b.langHtmlPath(cf.lang, cf.slug)
+	templatePath := convertMarkdownPathToHtmlPath(cf.slug + ".md")
+	template, _, err := b.lookUpTemplate(templatePath, cf.lang)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
-	defer distFile.Close()
-	template, err := b.lookUpTemplate(htmlPath)
-	if err != nil {
-		return err
+
+	var bundleDir string
+	if isPageBundle(cf.path) {
+		bundleDir = filepath.Dir(cf.path)
+		if err := copyBundle(bundleDir, filepath.Dir(filepath.Join(b.config.Dist, htmlPath))); err != nil {
+			return "", nil, err
+		}
 	}
 
-	filedata, err := b.getFileData(markdownPath)
+	filedata, err := b.getFileData(cf.path, bundleDir)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	// postSlug 処理
 	// TODO: ユーザー的に不要かもなのでどっかで消すか判断する
 	if filedata.FrontMatter.PostSlug == "" {
-		filedata.FrontMatter.PostSlug = filepath.ToSlash(strings.TrimSuffix(htmlPath, ".html"))
+		filedata.FrontMatter.PostSlug = filepath.ToSlash(cf.slug)
 	}
 
 	// og image 処理
 	if filedata.FrontMatter.OgImage == "" && filedata.FrontMatter.Emoji != "" {
-		imagePath := replaceExt(markdownPath, ".md", ".png")
+		imagePath := replaceExt(htmlPath, ".html", ".png")
 		imageDistPath := filepath.Join(b.config.Dist, imagePath)
+		if err := os.MkdirAll(filepath.Dir(imageDistPath), os.ModePerm); err != nil {
+			return "", nil, err
+		}
 		file, err := os.Create(imageDistPath)
 		if err != nil {
-			return err
+			return "", nil, err
 		}
 		defer file.Close()
 		if err := filedata.FrontMatter.SaveTwemojiPng(file); err != nil {
-			return err
+			return "", nil, err
 		}
 		filedata.FrontMatter.OgImage = filepath.ToSlash(imagePath)
 	}
 
-	renderContext, err := b.getRenderContext(filedata)
+	renderContext, err := b.getRenderContext(filedata, htmlPath, cf.lang, cf.slug)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
-	return template.FRender(distFile, renderContext)
+
+	var buf bytes.Buffer
+	if err := template.FRender(&buf, renderContext); err != nil {
+		return "", nil, err
+	}
+	return htmlPath, buf.Bytes(), nil
 }
 
-func (b Builder) getFileData(markdownPath string) (FileData, error) {
+func (b Builder) getFileData(markdownPath, bundleDir string) (FileData, error) {
 	var filedata FileData
 	filedata.Path = markdownPath
+	filedata.BundleDir = bundleDir
 	var buf bytes.Buffer
 	content, err := os.ReadFile(markdownPath)
 	if err != nil {
@@ -205,8 +435,12 @@ func (b Builder) getFileData(markdownPath string) (FileData, error) {
 	return filedata, nil
 }
 
-// getRenderContext returns a map[string]interface{} that contains the content of the markdown file.
-func (b Builder) getRenderContext(filedata FileData) (map[string]interface{}, error) {
+// getRenderContext returns a map[string]interface{} that contains the
+// content of the markdown file. htmlPath is its dist-relative output path,
+// used to resolve page bundle resources to public URLs. lang and slug are
+// the page's language and cross-language slug, used to expose .Lang,
+// .Translations and the {{#i18n}} helper.
+func (b Builder) getRenderContext(filedata FileData, htmlPath, lang, slug string) (map[string]interface{}, error) {
 	// make することで map のデータ競合を避ける
 	renderContext := make(map[string]interface{})
 
@@ -220,40 +454,94 @@ func (b Builder) getRenderContext(filedata FileData) (map[string]interface{}, er
 	for k, v := range filedata.FrontMatter.AsMap() {
 		renderContext[k] = v
 	}
+
+	renderContext["lang"] = lang
+	renderContext["translations"] = b.translationsFor(lang, slug)
+	table, err := loadTranslationTable(lang)
+	if err != nil {
+		return nil, err
+	}
+	renderContext["i18n"] = i18nLambda(table)
+
+	if filedata.BundleDir != "" {
+		publicDir := filepath.Dir(htmlPath)
+		resources := newResources(filedata.BundleDir, publicDir, b.config.Dist)
+		list, err := resources.List()
+		if err != nil {
+			return nil, err
+		}
+		renderContext["resources"] = list
+		for name, fn := range resources.mustacheFuncs() {
+			renderContext[name] = fn
+		}
+	}
 	return renderContext, nil
 }
 
-func (b *Builder) initTemplateMap(templateFiles []string) error {
-	m := make(map[string]*mustache.Template, len(templateFiles))
-	for _, templateFile := range templateFiles {
-		t, err := mustache.ParseFile(templateFile)
+// initTemplateMap parses every template under sources, keyed by path
+// relative to its source root. Sources are applied last source first, so
+// sources[0] (the project's own layouts) is parsed last and its templates
+// win over a module's on path collision.
+func (b *Builder) initTemplateMap(sources []module.FileSource) error {
+	m := make(map[string]*mustache.Template)
+	s := make(map[string][]byte)
+	for i := len(sources) - 1; i >= 0; i-- {
+		src := sources[i]
+		if !existDir(src.Root) {
+			continue
+		}
+		templateFiles, err := getFilePathsByExt(src.Root, ".html")
 		if err != nil {
 			return err
 		}
-		m[templateFile] = t
+		for _, templateFile := range templateFiles {
+			rel, err := filepath.Rel(src.Root, templateFile)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(templateFile)
+			if err != nil {
+				return err
+			}
+			t, err := mustache.ParseString(string(data))
+			if err != nil {
+				return err
+			}
+			key := filepath.ToSlash(rel)
+			m[key] = t
+			s[key] = data
+		}
 	}
 	b.templateMap = m
+	b.templateSource = s
 	return nil
 }
 
-// lookUpTemplate returns the path (file path) of the template path.
-func (b Builder) lookUpTemplate(path string) (*mustache.Template, error) {
+// lookUpTemplate returns the template for the given language-agnostic,
+// dist-relative path, and the templateMap key it resolved to (so its
+// source can be looked up for cache hashing). It tries lang's own
+// layouts/<lang>/... tree before falling back to the generic layout, then
+// the closest "default.html" in each.
+func (b Builder) lookUpTemplate(path, lang string) (*mustache.Template, string, error) {
 	dir := filepath.Dir(path)
-	layoutsDir := b.config.Layouts
 
-	t, ok := b.templateMap[filepath.Join(layoutsDir, path)]
-	if ok {
-		return t, nil
+	var candidates []string
+	if lang != "" {
+		candidates = append(candidates,
+			filepath.Join(lang, path),
+			filepath.Join(lang, dir, "default.html"),
+			filepath.Join(lang, "default.html"),
+		)
 	}
-	t, ok = b.templateMap[filepath.Join(layoutsDir, dir, "default.html")]
-	if ok {
-		return t, nil
-	}
-	t, ok = b.templateMap[filepath.Join(layoutsDir, "default.html")]
-	if ok {
-		return t, nil
+	candidates = append(candidates, path, filepath.Join(dir, "default.html"), "default.html")
+
+	for _, c := range candidates {
+		key := filepath.ToSlash(c)
+		if t, ok := b.templateMap[key]; ok {
+			return t, key, nil
+		}
 	}
-	return nil, errors.New("template not found")
+	return nil, "", errors.New("template not found")
 }
 
 func replaceExt(filePath, from, to string) string {
@@ -269,45 +557,99 @@ func convertMarkdownPathToHtmlPath(markdownPath string) string {
 	return replaceExt(markdownPath, ".md", ".html")
 }
 
-// copyStatic copy all files in the static directory (src) to the dist directory.
-func copyStatic(src, dist string) error {
-	if existDir(src) {
-		// Create destination directory if it does not exist
-		if err := os.MkdirAll(dist, os.ModePerm); err != nil {
+// copyStatic copies every static source into dist: each module's static
+// mount first, then the project's own static directory (sources[0]) last,
+// so the project always wins on path collision.
+func copyStatic(sources []module.FileSource, dist string) error {
+	any := false
+	for i := len(sources) - 1; i >= 0; i-- {
+		src := sources[i].Root
+		if !existDir(src) {
+			continue
+		}
+		any = true
+		if err := copyTree(src, dist); err != nil {
 			return err
 		}
+	}
+	if !any {
+		log.Printf("[INFO] static directory not found. skip copying static files.")
+	}
+	return nil
+}
+
+// copyTree recursively copies every file under src into dist, overwriting
+// any file already there with the same relative path.
+func copyTree(src, dist string) error {
+	// Create destination directory if it does not exist
+	if err := os.MkdirAll(dist, os.ModePerm); err != nil {
+		return err
+	}
+
+	// Get all files in the source directory
+	files, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
 
-		// Get all files in the source directory
-		files, err := os.ReadDir(src)
+	// Copy each file to the destination directory
+	for _, file := range files {
+		srcFile := filepath.Join(src, file.Name())
+		distFile := filepath.Join(dist, file.Name())
+
+		if file.IsDir() {
+			// Recursively copy subdirectories
+			if err := copyTree(srcFile, distFile); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Leave a file alone if dist already holds the same size and
+		// modification time, so repeated builds don't rewrite every
+		// static asset.
+		same, err := sameFile(srcFile, distFile)
 		if err != nil {
 			return err
 		}
+		if same {
+			continue
+		}
+		if err := copyFile(srcFile, distFile); err != nil {
+			return err
+		}
+	}
 
-		// Copy each file to the destination directory
-		for _, file := range files {
-			srcFile := filepath.Join(src, file.Name())
-			distFile := filepath.Join(dist, file.Name())
+	return nil
+}
 
-			if file.IsDir() {
-				// Recursively copy subdirectories
-				if err := copyStatic(srcFile, distFile); err != nil {
-					return err
-				}
-			} else {
-				// Copy file contents
-				if err := copyFile(srcFile, distFile); err != nil {
-					return err
-				}
-			}
+// copyBundle copies every non-markdown file in a page bundle directory
+// (src) next to its rendered HTML in dist (dist), so that e.g.
+// posts/hello/cover.jpg ends up at <dist>/posts/hello/cover.jpg. It does
+// not recurse into subdirectories of the bundle.
+func copyBundle(src, dist string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dist, os.ModePerm); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		if err := copyFile(filepath.Join(src, entry.Name()), filepath.Join(dist, entry.Name())); err != nil {
+			return err
 		}
-	} else {
-		log.Printf("[INFO] static directory not found. skip copying static files.")
 	}
-
 	return nil
 }
 
 // copyFile copies a file from src to dst.
+// copyFile copies a file from src to dst, and carries over src's
+// modification time so a later build can compare dst against a changed
+// src by size and mtime without reading either.
 func copyFile(src, dst string) error {
 	srcFile, err := os.Open(src)
 	if err != nil {
@@ -321,12 +663,15 @@ func copyFile(src, dst string) error {
 	}
 	defer dstFile.Close()
 
-	_, err = io.Copy(dstFile, srcFile)
-	if err != nil {
+	if _, err := io.Copy(dstFile, srcFile); err != nil {
 		return err
 	}
 
-	return nil
+	info, err := srcFile.Stat()
+	if err != nil {
+		return err
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
 }
 
 // existDir checks if a directory exists.
@@ -338,22 +683,22 @@ func existDir(dir string) bool {
 	return info.IsDir()
 }
 
-func createDistDir(dist string, overwrite bool) error {
-	// TODO: cache dist directory
-	if existDir(dist) {
-		if !overwrite {
-			return errors.New("dist directory already exists")
-		}
+// createDistDir ensures the dist directory exists. If clean is true (a
+// forced build), any existing dist directory is wiped first; otherwise an
+// existing directory is left as is, so the incremental build cache can
+// tell which of its files are still up to date.
+func createDistDir(dist string, clean bool) error {
+	if clean && existDir(dist) {
 		log.Printf("[INFO] re creating dist directory: %s", dist)
 		if err := os.RemoveAll(dist); err != nil {
 			return err
 		}
 	}
-	log.Printf("[INFO] creating dist directory: %s", dist)
-	if err := os.Mkdir(dist, os.ModePerm); err != nil {
-		return err
+	if existDir(dist) {
+		return nil
 	}
-	return nil
+	log.Printf("[INFO] creating dist directory: %s", dist)
+	return os.MkdirAll(dist, os.ModePerm)
 }
 
 func getFilePathsByExt(dirPath, ext string) ([]string, error) {
@@ -379,29 +724,12 @@ func getFilePathsByExt(dirPath, ext string) ([]string, error) {
 }
 
 func (b *Builder) initGoldmark() goldmark.Markdown {
-	// TODO: highlight は option にする(例: 他の syntax highlighter を使いたい場合のため)
 	extensions := []goldmark.Extender{
 		// default extensions
 		extension.GFM,
+		&highlight.Extension{Highlighter: b.initHighlighter()},
 	}
 	rendererOptions := []renderer.Option{}
-	highlightoptions := []highlighting.Option{}
-	if b.config.Build.Goldmark.HighlightConfig != nil {
-		if b.config.Build.Goldmark.HighlightConfig.Style != nil {
-			highlightoptions = append(highlightoptions, highlighting.WithStyle(*b.config.Build.Goldmark.HighlightConfig.Style))
-		}
-		// TODO: キーがない場合は highlight しないようにする
-		if b.config.Build.Goldmark.HighlightConfig.WithNumbers != nil {
-			highlightoptions = append(
-				highlightoptions,
-				highlighting.WithFormatOptions(chromahtml.WithLineNumbers(*b.config.Build.Goldmark.HighlightConfig.WithNumbers)),
-			)
-		}
-	}
-
-	if len(highlightoptions) > 0 {
-		extensions = append(extensions, highlighting.NewHighlighting(highlightoptions...))
-	}
 
 	// renderer options を設定
 	if b.config.Build.Goldmark.RendererOptions != nil {
@@ -417,13 +745,33 @@ func (b *Builder) initGoldmark() goldmark.Markdown {
 	)
 }
 
-func createDistFile(dist string) (*os.File, error) {
-	dir := filepath.Dir(dist)
-	if !existDir(dir) {
-		err := os.MkdirAll(dir, os.ModePerm)
-		if err != nil {
-			return nil, err
+// initHighlighter builds the fenced code block highlighter, per
+// highlight.engine in vss.toml (default "chroma"). Build.Goldmark.HighlightConfig's
+// Style/WithNumbers still configure the chroma engine; Highlight.Command
+// configures the command engine.
+func (b *Builder) initHighlighter() highlight.Highlighter {
+	engine := "chroma"
+	config := highlight.Config{}
+
+	if b.config.Build.Goldmark.HighlightConfig != nil {
+		if b.config.Build.Goldmark.HighlightConfig.Style != nil {
+			config.Style = *b.config.Build.Goldmark.HighlightConfig.Style
+		}
+		if b.config.Build.Goldmark.HighlightConfig.WithNumbers != nil {
+			config.WithNumbers = *b.config.Build.Goldmark.HighlightConfig.WithNumbers
 		}
 	}
-	return os.Create(dist)
+	if b.config.Highlight != nil {
+		if b.config.Highlight.Engine != "" {
+			engine = b.config.Highlight.Engine
+		}
+		config.Command = b.config.Highlight.Command
+	}
+
+	highlighter, err := highlight.Get(engine, config)
+	if err != nil {
+		log.Printf("[ERROR] %s, falling back to engine \"none\"", err)
+		highlighter, _ = highlight.Get("none", config)
+	}
+	return highlighter
 }
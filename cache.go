@@ -0,0 +1,103 @@
+package vss
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// cacheDir holds the incremental build manifest, alongside dist rather
+// than inside it so cleaning dist doesn't also erase it by accident.
+const cacheDir = ".vss-cache"
+
+const manifestFile = "manifest.json"
+
+// cacheEntry is one content file's record in the incremental build
+// manifest: the hashes that produced its last output, so a later Run can
+// tell whether re-rendering it would change anything.
+type cacheEntry struct {
+	InputHash    string `json:"inputHash"`    // sha256 of the markdown file's raw content
+	TemplateHash string `json:"templateHash"` // sha256 of its resolved template + front matter + config slice
+	OutputPath   string `json:"outputPath"`   // dist-relative
+	OutputHash   string `json:"outputHash"`   // sha256 of the rendered output
+}
+
+// loadManifest reads the incremental build cache. A missing or corrupt
+// manifest is not an error: it just means every file renders.
+func loadManifest() map[string]cacheEntry {
+	data, err := os.ReadFile(filepath.Join(cacheDir, manifestFile))
+	if err != nil {
+		return make(map[string]cacheEntry)
+	}
+	entries := make(map[string]cacheEntry)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return make(map[string]cacheEntry)
+	}
+	return entries
+}
+
+// saveManifest persists the incremental build cache.
+func saveManifest(entries map[string]cacheEntry) error {
+	if err := os.MkdirAll(cacheDir, os.ModePerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(cacheDir, manifestFile), data, 0o644)
+}
+
+// hashBytes returns the hex sha256 of the concatenation of chunks.
+func hashBytes(chunks ...[]byte) string {
+	h := sha256.New()
+	for _, c := range chunks {
+		h.Write(c)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// templateHash hashes everything besides a page's markdown body that can
+// change its output: the resolved template's own source, its front
+// matter, and the config-derived part of the render context.
+//
+// Each part is hashed to its own digest before being combined, rather
+// than concatenating the raw byte slices into one hasher: otherwise two
+// different (template, frontMatter) splits that happen to concatenate
+// to the same byte stream would hash identically, e.g. template "ab" +
+// frontMatter "c" vs. template "a" + frontMatter "bc".
+func templateHash(templateSource []byte, frontMatter, config map[string]interface{}) (string, error) {
+	fmJSON, err := json.Marshal(frontMatter)
+	if err != nil {
+		return "", err
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, part := range [][]byte{templateSource, fmJSON, configJSON} {
+		sum := sha256.Sum256(part)
+		h.Write(sum[:])
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sameFile reports whether dist already holds the same content as src,
+// judging by size and modification time rather than hashing every byte.
+func sameFile(src, dist string) (bool, error) {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return false, err
+	}
+	distInfo, err := os.Stat(dist)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return srcInfo.Size() == distInfo.Size() && srcInfo.ModTime().Equal(distInfo.ModTime()), nil
+}
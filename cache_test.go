@@ -0,0 +1,118 @@
+package vss
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHashBytesDeterministic(t *testing.T) {
+	a := hashBytes([]byte("hello"), []byte("world"))
+	b := hashBytes([]byte("hello"), []byte("world"))
+	if a != b {
+		t.Fatalf("hashBytes not deterministic: %q != %q", a, b)
+	}
+
+	c := hashBytes([]byte("hellow"), []byte("orld"))
+	if a != c {
+		t.Fatalf("hashBytes should collide across chunk boundaries when concatenation matches: %q != %q", a, c)
+	}
+}
+
+func TestTemplateHashDoesNotCollideAcrossFieldBoundaries(t *testing.T) {
+	// Two (template, frontMatter) splits whose template bytes and title
+	// concatenate to the same "ab" must still hash differently, since
+	// templateHash digests each part separately before combining them.
+	fm1 := map[string]interface{}{"title": "ab"}
+	fm2 := map[string]interface{}{"title": "b"}
+	config := map[string]interface{}{}
+
+	h1, err := templateHash([]byte(""), fm1, config)
+	if err != nil {
+		t.Fatalf("templateHash: %v", err)
+	}
+	h2, err := templateHash([]byte("a"), fm2, config)
+	if err != nil {
+		t.Fatalf("templateHash: %v", err)
+	}
+	if h1 == h2 {
+		t.Fatalf("templateHash collided across a template/frontMatter boundary: %q", h1)
+	}
+}
+
+func TestTemplateHashSameInputsSameHash(t *testing.T) {
+	fm := map[string]interface{}{"title": "hello"}
+	config := map[string]interface{}{"baseUrl": "https://example.com"}
+
+	h1, err := templateHash([]byte("template source"), fm, config)
+	if err != nil {
+		t.Fatalf("templateHash: %v", err)
+	}
+	h2, err := templateHash([]byte("template source"), fm, config)
+	if err != nil {
+		t.Fatalf("templateHash: %v", err)
+	}
+	if h1 != h2 {
+		t.Fatalf("templateHash not deterministic: %q != %q", h1, h2)
+	}
+
+	h3, err := templateHash([]byte("different source"), fm, config)
+	if err != nil {
+		t.Fatalf("templateHash: %v", err)
+	}
+	if h1 == h3 {
+		t.Fatalf("templateHash did not change when templateSource changed")
+	}
+}
+
+func TestSameFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	dist := filepath.Join(dir, "dist")
+
+	if err := os.WriteFile(src, []byte("hello"), 0o644); err != nil {
+		t.Fatalf("write src: %v", err)
+	}
+
+	same, err := sameFile(src, dist)
+	if err != nil {
+		t.Fatalf("sameFile: %v", err)
+	}
+	if same {
+		t.Fatalf("sameFile reported true for a dist path that does not exist")
+	}
+
+	if err := os.WriteFile(dist, []byte("hello"), 0o644); err != nil {
+		t.Fatalf("write dist: %v", err)
+	}
+	modTime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(src, modTime, modTime); err != nil {
+		t.Fatalf("chtimes src: %v", err)
+	}
+	if err := os.Chtimes(dist, modTime, modTime); err != nil {
+		t.Fatalf("chtimes dist: %v", err)
+	}
+
+	same, err = sameFile(src, dist)
+	if err != nil {
+		t.Fatalf("sameFile: %v", err)
+	}
+	if !same {
+		t.Fatalf("sameFile reported false for identical size and modtime")
+	}
+
+	if err := os.WriteFile(dist, []byte("hello!"), 0o644); err != nil {
+		t.Fatalf("rewrite dist: %v", err)
+	}
+	if err := os.Chtimes(dist, modTime, modTime); err != nil {
+		t.Fatalf("chtimes dist: %v", err)
+	}
+	same, err = sameFile(src, dist)
+	if err != nil {
+		t.Fatalf("sameFile: %v", err)
+	}
+	if same {
+		t.Fatalf("sameFile reported true for files with different sizes")
+	}
+}
@@ -0,0 +1,36 @@
+package vss
+
+import (
+	"github.com/mitchellh/cli"
+
+	"github.com/vssio/go-vss/internal/command"
+)
+
+// initCommands builds the CLI command table, injecting this package's
+// functions into the internal/command structs so that package does not
+// have to import this one.
+func initCommands(meta *command.Meta) map[string]cli.CommandFactory {
+	return map[string]cli.CommandFactory{
+		"build": func() (cli.Command, error) {
+			return &command.BuildCommand{Meta: *meta, Build: Build}, nil
+		},
+		"serve": func() (cli.Command, error) {
+			return &command.ServeCommand{Meta: *meta, Serve: Serve}, nil
+		},
+		"mod init": func() (cli.Command, error) {
+			return &command.ModInitCommand{Meta: *meta, Init: ModInit}, nil
+		},
+		"mod get": func() (cli.Command, error) {
+			return &command.ModGetCommand{Meta: *meta, Get: ModGet}, nil
+		},
+		"mod graph": func() (cli.Command, error) {
+			return &command.ModGraphCommand{Meta: *meta, Graph: ModGraph}, nil
+		},
+		"mod tidy": func() (cli.Command, error) {
+			return &command.ModTidyCommand{Meta: *meta, Tidy: ModTidy}, nil
+		},
+		"mod vendor": func() (cli.Command, error) {
+			return &command.ModVendorCommand{Meta: *meta, Vendor: ModVendor}, nil
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package vss
+
+import (
+	"os"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/vssio/go-vss/internal/module"
+)
+
+// configFile is the site manifest LoadConfig and SaveConfig read and
+// write, in the current directory.
+const configFile = "vss.toml"
+
+// Config is a site's configuration, loaded from vss.toml.
+type Config struct {
+	Dist    string `toml:"dist"`
+	Layouts string `toml:"layouts"`
+	Static  string `toml:"static"`
+	BaseUrl string `toml:"base_url"`
+
+	Build     BuildConfig      `toml:"build"`
+	Highlight *HighlightConfig `toml:"highlight"`
+	Module    *ModuleConfig    `toml:"module"`
+
+	// Languages maps a language code (e.g. "en", "ja") to its settings,
+	// for multilingual sites. A code absent here is never matched by a
+	// content file's filename suffix; see contentLang.
+	Languages                      map[string]LanguageConfig `toml:"languages"`
+	DefaultContentLanguage         string                    `toml:"default_content_language"`
+	DefaultContentLanguageInSubdir bool                      `toml:"default_content_language_in_subdir"`
+}
+
+// BuildConfig holds markdown rendering options.
+type BuildConfig struct {
+	IgnoreFiles []string       `toml:"ignore_files"`
+	Goldmark    GoldmarkConfig `toml:"goldmark"`
+}
+
+// GoldmarkConfig configures the markdown renderer.
+type GoldmarkConfig struct {
+	RendererOptions *RendererOptions         `toml:"renderer_options"`
+	HighlightConfig *GoldmarkHighlightConfig `toml:"highlight"`
+}
+
+// RendererOptions maps to goldmark's renderer/html options.
+type RendererOptions struct {
+	WithUnsafe *bool `toml:"unsafe"`
+}
+
+// GoldmarkHighlightConfig configures the chroma engine specifically.
+// HighlightConfig (Config.Highlight) selects the engine itself.
+type GoldmarkHighlightConfig struct {
+	Style       *string `toml:"style"`
+	WithNumbers *bool   `toml:"with_numbers"`
+}
+
+// HighlightConfig selects and configures the fenced code block
+// highlighter; see internal/highlight.
+type HighlightConfig struct {
+	Engine  string `toml:"engine"`
+	Command string `toml:"command"`
+}
+
+// LanguageConfig describes one entry of Config.Languages.
+type LanguageConfig struct {
+	Name    string                 `toml:"name"`
+	Weight  int                    `toml:"weight"`
+	BaseURL string                 `toml:"base_url"`
+	Params  map[string]interface{} `toml:"params"`
+}
+
+// ModuleConfig is the site's own module identity (for sites that are
+// themselves importable as a module) and the modules it imports.
+type ModuleConfig struct {
+	Path    string          `toml:"path"`
+	Imports []module.Import `toml:"imports"`
+}
+
+// AsMap returns config as a map for the base mustache render context
+// shared by every page, e.g. {{baseUrl}}.
+func (c *Config) AsMap() map[string]interface{} {
+	return map[string]interface{}{
+		"baseUrl": c.BaseUrl,
+	}
+}
+
+// LoadConfig reads the site's vss.toml from the current directory.
+func LoadConfig() (*Config, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// SaveConfig writes config back to vss.toml.
+func SaveConfig(config *Config) error {
+	data, err := toml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configFile, data, 0o644)
+}
@@ -0,0 +1,76 @@
+package vss
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// FileData holds a single content file's parsed markdown and front
+// matter, produced by Builder.getFileData.
+type FileData struct {
+	Path        string
+	BundleDir   string
+	Content     string
+	FrontMatter YamlFrontMatter
+}
+
+// YamlFrontMatter is a content file's front matter block.
+type YamlFrontMatter struct {
+	PostSlug string `yaml:"post_slug"`
+	OgImage  string `yaml:"og_image"`
+	Emoji    string `yaml:"emoji"`
+	Lang     string `yaml:"lang"`
+}
+
+// AsMap returns the front matter's fields for the page's render context,
+// omitting anything left unset.
+func (f YamlFrontMatter) AsMap() map[string]interface{} {
+	m := map[string]interface{}{}
+	if f.PostSlug != "" {
+		m["postSlug"] = f.PostSlug
+	}
+	if f.OgImage != "" {
+		m["ogImage"] = f.OgImage
+	}
+	if f.Emoji != "" {
+		m["emoji"] = f.Emoji
+	}
+	if f.Lang != "" {
+		m["lang"] = f.Lang
+	}
+	return m
+}
+
+// SaveTwemojiPng renders a placeholder Open Graph image for a page whose
+// front matter sets emoji but not og_image: the emoji text drawn centered
+// on a solid background in the basic 7x13 bitmap font. That font has no
+// emoji glyphs, so an emoji character comes out as its replacement glyph;
+// no Twemoji artwork is drawn. It exists so OgImage always resolves to a
+// real file.
+func (f YamlFrontMatter) SaveTwemojiPng(w io.Writer) error {
+	const width, height = 1200, 630
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 0x1a, G: 0x1a, B: 0x2e, A: 0xff}), image.Point{}, draw.Src)
+
+	face := basicfont.Face7x13
+	textWidth := font.MeasureString(face, f.Emoji).Ceil()
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(color.White),
+		Face: face,
+		Dot: fixed.Point26_6{
+			X: fixed.I((width - textWidth) / 2),
+			Y: fixed.I(height / 2),
+		},
+	}
+	drawer.DrawString(f.Emoji)
+
+	return png.Encode(w, img)
+}
@@ -0,0 +1,140 @@
+package vss
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/adrg/frontmatter"
+	"github.com/cbroglie/mustache"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// langSuffix matches a language code between the file's base name and its
+// ".md" extension, e.g. "post.en.md" or "post.pt-br.md".
+var langSuffix = regexp.MustCompile(`\.([a-zA-Z0-9-]+)\.md$`)
+
+// contentLang determines which language a content file belongs to and the
+// slug it shares with its translations, in priority order: a filename
+// suffix naming one of config.Languages, then front matter's lang field,
+// then config.DefaultContentLanguage. slug is relPath with any language
+// suffix and the .md extension stripped, so translations of the same page
+// resolve to the same slug regardless of which language named it.
+func contentLang(relPath, frontMatterLang string, config *Config) (lang, slug string) {
+	base := strings.TrimSuffix(relPath, ".md")
+	if m := langSuffix.FindStringSubmatch(relPath); m != nil {
+		if _, ok := config.Languages[m[1]]; ok {
+			return m[1], strings.TrimSuffix(base, "."+m[1])
+		}
+	}
+	if frontMatterLang != "" {
+		return frontMatterLang, base
+	}
+	return config.DefaultContentLanguage, base
+}
+
+// langHtmlPath returns the dist-relative output path for slug in lang:
+// under <lang>/ for every language except the default one, which is
+// written at the root unless config.DefaultContentLanguageInSubdir says
+// otherwise.
+func (b Builder) langHtmlPath(lang, slug string) string {
+	htmlPath := convertMarkdownPathToHtmlPath(slug + ".md")
+	if lang == b.config.DefaultContentLanguage && !b.config.DefaultContentLanguageInSubdir {
+		return htmlPath
+	}
+	return filepath.Join(lang, htmlPath)
+}
+
+// assignLanguages determines every content file's language and slug and
+// builds the cross-file translations index keyed by slug, so each page
+// can list its siblings in other languages. Front matter must be parsed
+// up front (not just at render time) because the index needs every
+// file's language decided before any one of them renders.
+func (b Builder) assignLanguages(files []contentFile) ([]contentFile, map[string]map[string]string, error) {
+	translations := make(map[string]map[string]string)
+	assigned := make([]contentFile, len(files))
+	for i, cf := range files {
+		content, err := os.ReadFile(cf.path)
+		if err != nil {
+			return nil, nil, err
+		}
+		var yfm YamlFrontMatter
+		if _, err := frontmatter.Parse(strings.NewReader(string(content)), &yfm); err != nil {
+			return nil, nil, err
+		}
+
+		cf.lang, cf.slug = contentLang(cf.relPath, yfm.Lang, b.config)
+		assigned[i] = cf
+
+		if translations[cf.slug] == nil {
+			translations[cf.slug] = make(map[string]string)
+		}
+		translations[cf.slug][cf.lang] = "/" + filepath.ToSlash(b.langHtmlPath(cf.lang, cf.slug))
+	}
+	return assigned, translations, nil
+}
+
+// translationsFor returns the sibling pages sharing slug in every language
+// but lang, sorted by language code, for the {{#translations}} template
+// section.
+func (b Builder) translationsFor(lang, slug string) []map[string]interface{} {
+	siblings := b.translations[slug]
+	langs := make([]string, 0, len(siblings))
+	for l := range siblings {
+		if l != lang {
+			langs = append(langs, l)
+		}
+	}
+	sort.Strings(langs)
+
+	out := make([]map[string]interface{}, 0, len(langs))
+	for _, l := range langs {
+		out = append(out, map[string]interface{}{"lang": l, "path": siblings[l]})
+	}
+	return out
+}
+
+// loadTranslationTable reads the translation table for lang from the
+// i18n/ directory, trying <lang>.toml then <lang>.yaml/.yml in turn. A
+// missing table is not an error: {{#i18n}} falls back to the key itself.
+func loadTranslationTable(lang string) (map[string]string, error) {
+	if lang == "" {
+		return nil, nil
+	}
+	for _, ext := range []string{".toml", ".yaml", ".yml"} {
+		data, err := os.ReadFile(filepath.Join("i18n", lang+ext))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		table := make(map[string]string)
+		if ext == ".toml" {
+			err = toml.Unmarshal(data, &table)
+		} else {
+			err = yaml.Unmarshal(data, &table)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return table, nil
+	}
+	return nil, nil
+}
+
+// i18nLambda returns the mustache.LambdaFunc backing
+// {{#i18n}}key{{/i18n}}: the section's text is the translation key,
+// looked up in table. A missing key renders as itself, so untranslated
+// strings stay visible instead of disappearing from the page.
+func i18nLambda(table map[string]string) func(string, mustache.RenderFunc) (string, error) {
+	return func(key string, render mustache.RenderFunc) (string, error) {
+		if v, ok := table[strings.TrimSpace(key)]; ok {
+			return v, nil
+		}
+		return key, nil
+	}
+}
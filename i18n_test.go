@@ -0,0 +1,125 @@
+package vss
+
+import (
+	"testing"
+
+	"github.com/cbroglie/mustache"
+)
+
+func testI18nConfig() *Config {
+	return &Config{
+		Languages: map[string]LanguageConfig{
+			"en": {},
+			"ja": {},
+		},
+		DefaultContentLanguage: "en",
+	}
+}
+
+func TestContentLangFilenameSuffix(t *testing.T) {
+	config := testI18nConfig()
+	lang, slug := contentLang("posts/hello.ja.md", "", config)
+	if lang != "ja" || slug != "posts/hello" {
+		t.Fatalf("contentLang = %q, %q; want %q, %q", lang, slug, "ja", "posts/hello")
+	}
+}
+
+func TestContentLangUnknownSuffixFallsBackToFrontMatter(t *testing.T) {
+	config := testI18nConfig()
+	// "fr" is not in config.Languages, so the suffix is not a language
+	// marker and the whole name (minus .md) is the slug.
+	lang, slug := contentLang("posts/hello.fr.md", "ja", config)
+	if lang != "ja" || slug != "posts/hello.fr" {
+		t.Fatalf("contentLang = %q, %q; want %q, %q", lang, slug, "ja", "posts/hello.fr")
+	}
+}
+
+func TestContentLangFrontMatter(t *testing.T) {
+	config := testI18nConfig()
+	lang, slug := contentLang("posts/hello.md", "ja", config)
+	if lang != "ja" || slug != "posts/hello" {
+		t.Fatalf("contentLang = %q, %q; want %q, %q", lang, slug, "ja", "posts/hello")
+	}
+}
+
+func TestContentLangDefault(t *testing.T) {
+	config := testI18nConfig()
+	lang, slug := contentLang("posts/hello.md", "", config)
+	if lang != "en" || slug != "posts/hello" {
+		t.Fatalf("contentLang = %q, %q; want %q, %q", lang, slug, "en", "posts/hello")
+	}
+}
+
+func TestLangHtmlPath(t *testing.T) {
+	b := Builder{config: testI18nConfig()}
+
+	if got, want := b.langHtmlPath("en", "posts/hello"), convertMarkdownPathToHtmlPath("posts/hello.md"); got != want {
+		t.Fatalf("langHtmlPath(en) = %q; want %q", got, want)
+	}
+	if got, want := b.langHtmlPath("ja", "posts/hello"), "ja/posts/hello.html"; got != want {
+		t.Fatalf("langHtmlPath(ja) = %q; want %q", got, want)
+	}
+
+	b.config.DefaultContentLanguageInSubdir = true
+	if got, want := b.langHtmlPath("en", "posts/hello"), "en/posts/hello.html"; got != want {
+		t.Fatalf("langHtmlPath(en, subdir) = %q; want %q", got, want)
+	}
+}
+
+func TestTranslationsFor(t *testing.T) {
+	b := Builder{
+		translations: map[string]map[string]string{
+			"posts/hello": {
+				"en": "/posts/hello.html",
+				"ja": "/ja/posts/hello.html",
+				"fr": "/fr/posts/hello.html",
+			},
+		},
+	}
+
+	got := b.translationsFor("ja", "posts/hello")
+	if len(got) != 2 {
+		t.Fatalf("translationsFor returned %d entries, want 2", len(got))
+	}
+	if got[0]["lang"] != "en" || got[1]["lang"] != "fr" {
+		t.Fatalf("translationsFor not sorted by lang: %+v", got)
+	}
+	if got[0]["path"] != "/posts/hello.html" {
+		t.Fatalf("translationsFor path = %v; want /posts/hello.html", got[0]["path"])
+	}
+}
+
+func TestTranslationsForNoSiblings(t *testing.T) {
+	b := Builder{translations: map[string]map[string]string{}}
+	got := b.translationsFor("en", "posts/missing")
+	if len(got) != 0 {
+		t.Fatalf("translationsFor for a slug with no siblings = %+v; want empty", got)
+	}
+}
+
+func TestI18nLambda(t *testing.T) {
+	lambda := i18nLambda(map[string]string{"greeting": "hello"})
+	if got, err := lambda("greeting", nil); err != nil || got != "hello" {
+		t.Fatalf("i18nLambda(greeting) = %q, %v; want hello, nil", got, err)
+	}
+	if got, err := lambda(" greeting ", nil); err != nil || got != "hello" {
+		t.Fatalf("i18nLambda trims whitespace around the key, got %q, %v", got, err)
+	}
+	if got, err := lambda("missing", nil); err != nil || got != "missing" {
+		t.Fatalf("i18nLambda(missing) = %q, %v; want the key back unchanged", got, err)
+	}
+}
+
+// TestI18nLambdaRendersThroughMustache renders {{#i18n}}{{/i18n}} through
+// mustache.Render, the same call path Builder.Run uses. mustache only
+// accepts section lambdas matching its 2-in/2-out LambdaFunc signature.
+func TestI18nLambdaRendersThroughMustache(t *testing.T) {
+	lambda := i18nLambda(map[string]string{"greeting": "hello"})
+	out, err := mustache.Render("{{#i18n}}greeting{{/i18n}}", map[string]interface{}{"i18n": lambda})
+	if err != nil {
+		t.Fatalf("mustache.Render: %v", err)
+	}
+	if out != "hello" {
+		t.Fatalf("mustache.Render = %q; want hello", out)
+	}
+}
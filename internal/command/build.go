@@ -0,0 +1,40 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+)
+
+// BuildCommand builds the site into its dist directory. The actual build
+// logic lives in package vss (as vss.Build) and is injected here so this
+// package does not have to import vss, which already imports this
+// package.
+type BuildCommand struct {
+	Meta
+	Build func(force bool) error
+}
+
+func (c *BuildCommand) Help() string {
+	return "Usage: vss build [-force]\n\n" +
+		"  Build the site into its dist directory.\n" +
+		"  -force bypasses the incremental build cache, rebuilding everything."
+}
+
+func (c *BuildCommand) Synopsis() string {
+	return "Build the site"
+}
+
+func (c *BuildCommand) Run(args []string) int {
+	flags := flag.NewFlagSet("build", flag.ContinueOnError)
+	force := flags.Bool("force", false, "bypass the incremental build cache")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := c.Build(*force); err != nil {
+		c.UI.Error(fmt.Sprintf("build: %s", err))
+		return 1
+	}
+	c.UI.Info("build complete")
+	return 0
+}
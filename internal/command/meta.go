@@ -0,0 +1,8 @@
+package command
+
+import "github.com/mitchellh/cli"
+
+// Meta contains the state shared by every CLI command.
+type Meta struct {
+	UI cli.Ui
+}
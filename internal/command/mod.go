@@ -0,0 +1,137 @@
+// Package command's mod_*.go files implement `vss mod`: managing theme and
+// component modules the way `go mod` manages Go dependencies. The actual
+// load/resolve/save logic lives in package vss (vss.ModInit and friends)
+// and is injected here so this package does not have to import vss, which
+// already imports this package.
+package command
+
+import "fmt"
+
+// ModInitCommand sets the current site's own module path, so other sites
+// can import it.
+type ModInitCommand struct {
+	Meta
+	Init func(path string) error
+}
+
+func (c *ModInitCommand) Help() string {
+	return "Usage: vss mod init <module-path>\n\n  Set the site's own module path, e.g. github.com/owner/theme."
+}
+
+func (c *ModInitCommand) Synopsis() string {
+	return "Set the site's module path"
+}
+
+func (c *ModInitCommand) Run(args []string) int {
+	if len(args) != 1 {
+		c.UI.Error("mod init requires exactly one argument: the module path")
+		return 1
+	}
+	if err := c.Init(args[0]); err != nil {
+		c.UI.Error(fmt.Sprintf("mod init: %s", err))
+		return 1
+	}
+	c.UI.Info(fmt.Sprintf("module path set to %s", args[0]))
+	return 0
+}
+
+// ModGetCommand adds (or updates) one module import.
+type ModGetCommand struct {
+	Meta
+	Get func(pathVersion string) error
+}
+
+func (c *ModGetCommand) Help() string {
+	return "Usage: vss mod get <path@version>\n\n  Fetch a module and add it to the site's imports."
+}
+
+func (c *ModGetCommand) Synopsis() string {
+	return "Add or update a module import"
+}
+
+func (c *ModGetCommand) Run(args []string) int {
+	if len(args) != 1 {
+		c.UI.Error("mod get requires exactly one argument: <path@version>")
+		return 1
+	}
+	if err := c.Get(args[0]); err != nil {
+		c.UI.Error(fmt.Sprintf("mod get: %s", err))
+		return 1
+	}
+	c.UI.Info(fmt.Sprintf("added %s", args[0]))
+	return 0
+}
+
+// ModGraphCommand prints the resolved module graph, one "path@version
+// requires path@version" line per edge, like `go mod graph`.
+type ModGraphCommand struct {
+	Meta
+	Graph func() (string, error)
+}
+
+func (c *ModGraphCommand) Help() string {
+	return "Usage: vss mod graph\n\n  Print the resolved module dependency graph."
+}
+
+func (c *ModGraphCommand) Synopsis() string {
+	return "Print the module dependency graph"
+}
+
+func (c *ModGraphCommand) Run(args []string) int {
+	graph, err := c.Graph()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("mod graph: %s", err))
+		return 1
+	}
+	c.UI.Output(graph)
+	return 0
+}
+
+// ModTidyCommand re-resolves the module graph and rewrites the imports
+// list to the versions minimal version selection actually chose.
+type ModTidyCommand struct {
+	Meta
+	Tidy func() error
+}
+
+func (c *ModTidyCommand) Help() string {
+	return "Usage: vss mod tidy\n\n  Re-resolve modules and update the imports list to the selected versions."
+}
+
+func (c *ModTidyCommand) Synopsis() string {
+	return "Clean up the module imports list"
+}
+
+func (c *ModTidyCommand) Run(args []string) int {
+	if err := c.Tidy(); err != nil {
+		c.UI.Error(fmt.Sprintf("mod tidy: %s", err))
+		return 1
+	}
+	c.UI.Info("modules tidied")
+	return 0
+}
+
+// ModVendorCommand copies every resolved module's mounted directories into
+// ./vendor/modules. Builds do not read from it; they resolve modules
+// through the module cache.
+type ModVendorCommand struct {
+	Meta
+	Vendor func() error
+}
+
+func (c *ModVendorCommand) Help() string {
+	return "Usage: vss mod vendor\n\n  Copy imported modules' mounts into ./vendor/modules."
+}
+
+func (c *ModVendorCommand) Synopsis() string {
+	return "Vendor imported modules locally"
+}
+
+func (c *ModVendorCommand) Run(args []string) int {
+	if err := c.Vendor(); err != nil {
+		c.UI.Error(fmt.Sprintf("mod vendor: %s", err))
+		return 1
+	}
+	c.UI.Info("vendored modules into vendor/modules")
+	return 0
+}
@@ -0,0 +1,48 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ServeCommand runs a local development server that rebuilds the site and
+// live-reloads the browser whenever content, layouts or static files
+// change. The actual build/watch/serve logic lives in package vss (as
+// vss.Serve) and is injected here so this package does not have to import
+// vss, which already imports this package.
+type ServeCommand struct {
+	Meta
+	Serve func(ctx context.Context, addr string) error
+}
+
+func (c *ServeCommand) Help() string {
+	return "Usage: vss serve [-addr 127.0.0.1:1313]\n\n" +
+		"  Build the site, serve it locally, and rebuild on change with\n" +
+		"  live reload in the browser."
+}
+
+func (c *ServeCommand) Synopsis() string {
+	return "Serve the site locally with live reload"
+}
+
+func (c *ServeCommand) Run(args []string) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", "127.0.0.1:1313", "address to serve the site on")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	c.UI.Info(fmt.Sprintf("serving on http://%s (ctrl-c to stop)", *addr))
+	if err := c.Serve(ctx, *addr); err != nil {
+		c.UI.Error(fmt.Sprintf("serve: %s", err))
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,50 @@
+package highlight
+
+import (
+	"io"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+func init() {
+	Register("chroma", newChromaHighlighter)
+}
+
+// chromaHighlighter is the default engine: the chroma library used
+// directly, in place of the previous goldmark-highlighting extension.
+type chromaHighlighter struct {
+	style       *chroma.Style
+	withNumbers bool
+}
+
+func newChromaHighlighter(config Config) (Highlighter, error) {
+	style := styles.Fallback
+	if config.Style != "" {
+		if s := styles.Get(config.Style); s != nil {
+			style = s
+		}
+	}
+	return &chromaHighlighter{style: style, withNumbers: config.WithNumbers}, nil
+}
+
+func (h *chromaHighlighter) Highlight(w io.Writer, source, lang string) error {
+	lexer := lexers.Get(lang)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	iterator, err := lexer.Tokenise(nil, source)
+	if err != nil {
+		return err
+	}
+
+	var opts []chromahtml.Option
+	if h.withNumbers {
+		opts = append(opts, chromahtml.WithLineNumbers(true))
+	}
+	return chromahtml.New(opts...).Format(w, h.style, iterator)
+}
@@ -0,0 +1,46 @@
+package highlight
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+func init() {
+	Register("command", newCommandHighlighter)
+}
+
+// commandHighlighter shells out to an external highlighter binary such as
+// pygmentize or bat, piping source on stdin and writing its stdout
+// verbatim as the code block's HTML.
+type commandHighlighter struct {
+	command string
+}
+
+func newCommandHighlighter(config Config) (Highlighter, error) {
+	if config.Command == "" {
+		return nil, fmt.Errorf("highlight: command engine requires highlight.command in vss.toml")
+	}
+	return &commandHighlighter{command: config.Command}, nil
+}
+
+func (h *commandHighlighter) Highlight(w io.Writer, source, lang string) error {
+	fields := strings.Fields(h.command)
+	args := make([]string, len(fields)-1)
+	for i, f := range fields[1:] {
+		args[i] = strings.ReplaceAll(f, "{lang}", lang)
+	}
+
+	cmd := exec.Command(fields[0], args...)
+	cmd.Stdin = strings.NewReader(source)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("highlight: %s: %w: %s", h.command, err, stderr.String())
+	}
+	_, err := w.Write(out.Bytes())
+	return err
+}
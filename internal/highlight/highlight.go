@@ -0,0 +1,51 @@
+// Package highlight implements the pluggable syntax highlighter used for
+// fenced code blocks: a small Highlighter interface, a registry of named
+// engines, and built-in chroma, none and command engines.
+package highlight
+
+import (
+	"fmt"
+	"io"
+)
+
+// Highlighter renders a fenced code block's source as a highlighted HTML
+// fragment, writing directly to w.
+type Highlighter interface {
+	Highlight(w io.Writer, source, lang string) error
+}
+
+// Config is the highlighter settings from vss.toml every engine can see
+// (Style and WithNumbers from [build.goldmark.highlight], Command from
+// [highlight]); each engine reads only the fields it understands.
+type Config struct {
+	// Style is the chroma style name (e.g. "monokai"), used by the chroma
+	// engine.
+	Style string
+	// WithNumbers adds line numbers, used by the chroma engine.
+	WithNumbers bool
+	// Command is the external binary (plus arguments) the command engine
+	// shells out to, e.g. "pygmentize -f html".
+	Command string
+}
+
+// Factory builds a Highlighter from a Config. Built-in engines register
+// one via init().
+type Factory func(Config) (Highlighter, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a highlighter engine under name, for use as
+// highlight.engine in vss.toml.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// Get builds the named engine. It returns an error if name is not
+// registered.
+func Get(name string, config Config) (Highlighter, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("highlight: unknown engine %q", name)
+	}
+	return factory(config)
+}
@@ -0,0 +1,28 @@
+package highlight
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+func init() {
+	Register("none", newNoneHighlighter)
+}
+
+// noneHighlighter is a pass-through engine: no tokenizing, just an escaped
+// <pre><code class="language-X">, for sites that highlight client-side.
+type noneHighlighter struct{}
+
+func newNoneHighlighter(Config) (Highlighter, error) {
+	return noneHighlighter{}, nil
+}
+
+func (noneHighlighter) Highlight(w io.Writer, source, lang string) error {
+	class := "language-text"
+	if lang != "" {
+		class = "language-" + lang
+	}
+	_, err := fmt.Fprintf(w, `<pre><code class="%s">%s</code></pre>`, class, html.EscapeString(source))
+	return err
+}
@@ -0,0 +1,236 @@
+// Package imageproc implements the image derivative pipeline used by page
+// bundle resources: Resize, Fill and Fit operations backed by
+// golang.org/x/image/draw, cached by a hash of their inputs.
+package imageproc
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// Op is a supported image transform operation.
+type Op string
+
+const (
+	// OpResize scales the image to fit within WxH, preserving aspect ratio.
+	OpResize Op = "resize"
+	// OpFill scales and crops the image to exactly WxH, anchored per Anchor.
+	OpFill Op = "fill"
+	// OpFit scales the image down to fit within WxH without cropping.
+	OpFit Op = "fit"
+)
+
+// Anchor controls which part of the source image Fill keeps when cropping.
+type Anchor string
+
+const (
+	AnchorCenter Anchor = "center"
+	AnchorTop    Anchor = "top"
+	AnchorBottom Anchor = "bottom"
+	AnchorLeft   Anchor = "left"
+	AnchorRight  Anchor = "right"
+)
+
+// Spec describes one derivative to produce from a source image.
+type Spec struct {
+	Op     Op
+	Width  int
+	Height int
+	Anchor Anchor
+}
+
+// String renders the spec the way it would appear in a template call, e.g.
+// "600x400 center". Used as part of the cache key.
+func (s Spec) String() string {
+	return fmt.Sprintf("%dx%d %s", s.Width, s.Height, s.Anchor)
+}
+
+// ParseBox parses a "WxH" box, e.g. "600x400".
+func ParseBox(box string) (width, height int, err error) {
+	w, h, ok := strings.Cut(box, "x")
+	if !ok {
+		return 0, 0, fmt.Errorf("imageproc: invalid box %q, want WxH", box)
+	}
+	width, err = strconv.Atoi(w)
+	if err != nil {
+		return 0, 0, fmt.Errorf("imageproc: invalid width in box %q: %w", box, err)
+	}
+	height, err = strconv.Atoi(h)
+	if err != nil {
+		return 0, 0, fmt.Errorf("imageproc: invalid height in box %q: %w", box, err)
+	}
+	return width, height, nil
+}
+
+// CacheKey returns the sha1 hex digest of (source path, mod time, op,
+// params), used to name cached derivatives under
+// resources/_gen/images/<hash>.<ext>.
+func CacheKey(srcPath string, modTime int64, spec Spec) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s|%d|%s|%s", srcPath, modTime, spec.Op, spec)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// CachePath returns the dist-relative path of the cached derivative for
+// srcPath/modTime/spec, reusing the source extension.
+func CachePath(srcPath string, modTime int64, spec Spec) string {
+	ext := filepath.Ext(srcPath)
+	if ext == "" {
+		ext = ".png"
+	}
+	return filepath.Join("resources", "_gen", "images", CacheKey(srcPath, modTime, spec)+ext)
+}
+
+// Process decodes the image at srcPath, applies spec, and writes the result
+// to dstPath, creating any missing parent directories. If dstPath already
+// exists it is left untouched and Process returns immediately, so callers
+// can skip re-encoding on cache hits.
+func Process(srcPath, dstPath string, spec Spec) error {
+	if _, err := os.Stat(dstPath); err == nil {
+		return nil
+	}
+
+	src, format, err := decode(srcPath)
+	if err != nil {
+		return fmt.Errorf("imageproc: decode %s: %w", srcPath, err)
+	}
+
+	var dst image.Image
+	switch spec.Op {
+	case OpResize:
+		dst = resize(src, spec.Width, spec.Height)
+	case OpFill:
+		dst = fill(src, spec.Width, spec.Height, spec.Anchor)
+	case OpFit:
+		dst = fit(src, spec.Width, spec.Height)
+	default:
+		return fmt.Errorf("imageproc: unknown op %q", spec.Op)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dstPath), os.ModePerm); err != nil {
+		return err
+	}
+	out, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return encode(out, dst, format)
+}
+
+func decode(path string) (image.Image, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+	img, format, err := image.Decode(f)
+	if err != nil {
+		return nil, "", err
+	}
+	return img, format, nil
+}
+
+func encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case "png":
+		return png.Encode(w, img)
+	case "gif":
+		return gif.Encode(w, img, nil)
+	default:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+	}
+}
+
+// resize scales src so it fits within w x h, preserving aspect ratio. Either
+// w or h may be 0, in which case it is derived from the other to keep the
+// source's aspect ratio.
+func resize(src image.Image, w, h int) image.Image {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	switch {
+	case w == 0:
+		w = sw * h / sh
+	case h == 0:
+		h = sh * w / sw
+	default:
+		scale := minFloat(float64(w)/float64(sw), float64(h)/float64(sh))
+		w, h = int(float64(sw)*scale+0.5), int(float64(sh)*scale+0.5)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, sb, draw.Over, nil)
+	return dst
+}
+
+// fit scales src down to fit within w x h without cropping, preserving
+// aspect ratio; it never scales up.
+func fit(src image.Image, w, h int) image.Image {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	scale := minFloat(float64(w)/float64(sw), float64(h)/float64(sh))
+	if scale > 1 {
+		scale = 1
+	}
+	return resize(src, int(float64(sw)*scale), int(float64(sh)*scale))
+}
+
+// fill scales src to cover w x h and crops to exactly that box, keeping the
+// region nearest anchor.
+func fill(src image.Image, w, h int, anchor Anchor) image.Image {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	scale := maxFloat(float64(w)/float64(sw), float64(h)/float64(sh))
+	scaledW, scaledH := int(float64(sw)*scale+0.5), int(float64(sh)*scale+0.5)
+
+	scaled := image.NewRGBA(image.Rect(0, 0, scaledW, scaledH))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), src, sb, draw.Over, nil)
+
+	ox, oy := cropOrigin(scaledW, scaledH, w, h, anchor)
+	cropRect := image.Rect(ox, oy, ox+w, oy+h)
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), scaled, cropRect.Min, draw.Src)
+	return dst
+}
+
+// cropOrigin returns the top-left point of a w x h window inside a
+// scaledW x scaledH image, positioned according to anchor.
+func cropOrigin(scaledW, scaledH, w, h int, anchor Anchor) (x, y int) {
+	x = (scaledW - w) / 2
+	y = (scaledH - h) / 2
+	switch anchor {
+	case AnchorTop:
+		y = 0
+	case AnchorBottom:
+		y = scaledH - h
+	case AnchorLeft:
+		x = 0
+	case AnchorRight:
+		x = scaledW - w
+	}
+	return x, y
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
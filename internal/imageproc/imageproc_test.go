@@ -0,0 +1,152 @@
+package imageproc
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseBox(t *testing.T) {
+	w, h, err := ParseBox("600x400")
+	if err != nil {
+		t.Fatalf("ParseBox: %v", err)
+	}
+	if w != 600 || h != 400 {
+		t.Fatalf("ParseBox(%q) = %d, %d; want 600, 400", "600x400", w, h)
+	}
+
+	for _, box := range []string{"600", "600x", "xoops", "600xabc"} {
+		if _, _, err := ParseBox(box); err == nil {
+			t.Errorf("ParseBox(%q) succeeded, want error", box)
+		}
+	}
+}
+
+func TestCropOrigin(t *testing.T) {
+	cases := []struct {
+		anchor Anchor
+		x, y   int
+	}{
+		{AnchorCenter, 50, 100},
+		{AnchorTop, 50, 0},
+		{AnchorBottom, 50, 200},
+		{AnchorLeft, 0, 100},
+		{AnchorRight, 100, 100},
+	}
+	// scaled 300x500, window 200x300:
+	// center x = (300-200)/2 = 50, center y = (500-300)/2 = 100
+	// right x = 300-200 = 100, bottom y = 500-300 = 200
+	for _, c := range cases {
+		x, y := cropOrigin(300, 500, 200, 300, c.anchor)
+		if x != c.x || y != c.y {
+			t.Errorf("cropOrigin(anchor=%s) = %d, %d; want %d, %d", c.anchor, x, y, c.x, c.y)
+		}
+	}
+}
+
+func TestCacheKeyDeterministic(t *testing.T) {
+	spec := Spec{Op: OpFill, Width: 600, Height: 400, Anchor: AnchorCenter}
+	k1 := CacheKey("a.png", 1000, spec)
+	k2 := CacheKey("a.png", 1000, spec)
+	if k1 != k2 {
+		t.Fatalf("CacheKey not deterministic: %q != %q", k1, k2)
+	}
+
+	k3 := CacheKey("b.png", 1000, spec)
+	if k1 == k3 {
+		t.Fatalf("CacheKey did not change with srcPath")
+	}
+
+	k4 := CacheKey("a.png", 2000, spec)
+	if k1 == k4 {
+		t.Fatalf("CacheKey did not change with modTime")
+	}
+
+	otherSpec := Spec{Op: OpFill, Width: 600, Height: 400, Anchor: AnchorTop}
+	k5 := CacheKey("a.png", 1000, otherSpec)
+	if k1 == k5 {
+		t.Fatalf("CacheKey did not change with spec")
+	}
+}
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "src.png")
+	writeTestPNG(t, srcPath, 400, 200)
+
+	cases := []struct {
+		name  string
+		spec  Spec
+		wantW int
+		wantH int
+	}{
+		{"resize", Spec{Op: OpResize, Width: 200, Height: 100}, 200, 100},
+		{"fit", Spec{Op: OpFit, Width: 200, Height: 200}, 200, 100},
+		{"fill", Spec{Op: OpFill, Width: 100, Height: 100, Anchor: AnchorCenter}, 100, 100},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dstPath := filepath.Join(dir, c.name+".png")
+			if err := Process(srcPath, dstPath, c.spec); err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			f, err := os.Open(dstPath)
+			if err != nil {
+				t.Fatalf("open output: %v", err)
+			}
+			defer f.Close()
+			img, _, err := image.Decode(f)
+			if err != nil {
+				t.Fatalf("decode output: %v", err)
+			}
+			b := img.Bounds()
+			if b.Dx() != c.wantW || b.Dy() != c.wantH {
+				t.Fatalf("output size = %dx%d; want %dx%d", b.Dx(), b.Dy(), c.wantW, c.wantH)
+			}
+		})
+	}
+}
+
+func TestProcessSkipsExistingDest(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "src.png")
+	writeTestPNG(t, srcPath, 400, 200)
+
+	dstPath := filepath.Join(dir, "dst.png")
+	if err := os.WriteFile(dstPath, []byte("not a real png"), 0o644); err != nil {
+		t.Fatalf("seed dst: %v", err)
+	}
+
+	if err := Process(srcPath, dstPath, Spec{Op: OpResize, Width: 100, Height: 50}); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	data, err := os.ReadFile(dstPath)
+	if err != nil {
+		t.Fatalf("read dst: %v", err)
+	}
+	if string(data) != "not a real png" {
+		t.Fatalf("Process overwrote an existing dstPath")
+	}
+}
+
+func writeTestPNG(t *testing.T, path string, w, h int) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0, A: 0xff})
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create %s: %v", path, err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("encode %s: %v", path, err)
+	}
+}
@@ -0,0 +1,26 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// CacheDir returns the root directory modules are downloaded into:
+// $XDG_CACHE_HOME/vss/modules, falling back to ~/.cache/vss/modules.
+func CacheDir() (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(base, "vss", "modules"), nil
+}
+
+// Path returns the on-disk directory a given module@version is (or would
+// be) checked out to.
+func Path(cacheDir, path, version string) string {
+	return filepath.Join(cacheDir, path+"@"+version)
+}
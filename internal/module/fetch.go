@@ -0,0 +1,32 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// fetch checks out path@version into dest via git clone + checkout, unless
+// dest already exists (modules are immutable once cached by version).
+func fetch(path, version, dest string) error {
+	if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		return nil
+	}
+
+	url := "https://" + path + ".git"
+	if err := runGit("", "clone", "--quiet", url, dest); err != nil {
+		return fmt.Errorf("module: clone %s: %w", path, err)
+	}
+	if err := runGit(dest, "checkout", "--quiet", version); err != nil {
+		return fmt.Errorf("module: checkout %s@%s: %w", path, version, err)
+	}
+	return nil
+}
+
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,46 @@
+// Package module implements vss's theme/module system: importing reusable
+// layouts, static assets and content from other git repositories, resolved
+// with Go-style minimal version selection and cached on disk.
+package module
+
+import (
+	"fmt"
+)
+
+// MountTarget is one of the component types a site is made of. Modules
+// mount their own directories onto these.
+type MountTarget string
+
+const (
+	MountLayouts MountTarget = "layouts"
+	MountStatic  MountTarget = "static"
+	MountContent MountTarget = "content"
+)
+
+// Mount maps a directory inside a module onto one of the site's own
+// component directories. Source is relative to the module root; Target is
+// one of layouts, static or content.
+type Mount struct {
+	Source string      `toml:"source"`
+	Target MountTarget `toml:"target"`
+}
+
+// Import is one entry of a site's (or module's) module.imports list: a
+// module path plus the version to use and the mounts to take from it.
+type Import struct {
+	Path    string  `toml:"path"`    // e.g. "github.com/owner/theme"
+	Version string  `toml:"version"` // semver tag, e.g. "v1.2.0"
+	Mounts  []Mount `toml:"mounts"`
+}
+
+func (i Import) String() string {
+	return fmt.Sprintf("%s@%s", i.Path, i.Version)
+}
+
+// Module is a resolved, on-disk module: an import plus where it was
+// checked out and the further imports it declares itself.
+type Module struct {
+	Import
+	Dir      string   // on-disk path under the module cache
+	Requires []Import // the module's own module.imports, for graph/tidy
+}
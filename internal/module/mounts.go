@@ -0,0 +1,34 @@
+package module
+
+import "path/filepath"
+
+// FileSource is one physical directory files of a given component type can
+// be read from: either the project's own directory, or a module's mount.
+type FileSource struct {
+	Root   string // directory on disk to read from
+	Module string // module path this mount came from, "" for the project
+}
+
+// Overlay returns the ordered list of directories to search for files of
+// the given target (layouts/static/content): the project's own directory
+// first, then each module's mount for that target in import order.
+// Callers that look up a single file should search in this order and stop
+// at the first match, so the project always wins on path collision;
+// callers that copy a whole tree (copyStatic) should instead apply sources
+// last-to-first, so a later copy from the project overwrites one from a
+// module.
+func Overlay(projectDir string, modules []Module, target MountTarget) []FileSource {
+	sources := []FileSource{{Root: projectDir}}
+	for _, m := range modules {
+		for _, mount := range m.Mounts {
+			if mount.Target != target {
+				continue
+			}
+			sources = append(sources, FileSource{
+				Root:   filepath.Join(m.Dir, mount.Source),
+				Module: m.Path,
+			})
+		}
+	}
+	return sources
+}
@@ -0,0 +1,111 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// manifest is the subset of a module's own vss.toml this package cares
+// about: the further modules it itself imports.
+type manifest struct {
+	Module struct {
+		Imports []Import `toml:"imports"`
+	} `toml:"module"`
+}
+
+// readRequires reads a checked-out module's own module.imports, if any.
+func readRequires(dir string) ([]Import, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "vss.toml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := toml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m.Module.Imports, nil
+}
+
+// Resolve fetches imports and their transitive requirements into the
+// module cache, and applies minimal version selection: for each module
+// path, the lowest version that is still high enough to satisfy every
+// requirement for it found anywhere in the graph. Since every requirement
+// is itself a lower bound, that is the highest version requested for the
+// path. The returned modules carry the mounts declared by the site's own
+// top-level imports; transitive modules contribute no mounts of their own.
+func Resolve(imports []Import) ([]Module, error) {
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	mountsByPath := make(map[string][]Mount, len(imports))
+	for _, imp := range imports {
+		mountsByPath[imp.Path] = imp.Mounts
+	}
+
+	selected := make(map[string]string)
+	queue := append([]Import(nil), imports...)
+	for len(queue) > 0 {
+		imp := queue[0]
+		queue = queue[1:]
+
+		if cur, ok := selected[imp.Path]; ok {
+			higher, err := higherVersion(cur, imp.Version)
+			if err != nil {
+				return nil, err
+			}
+			if higher == cur {
+				continue
+			}
+		}
+		selected[imp.Path] = imp.Version
+
+		dest := Path(cacheDir, imp.Path, imp.Version)
+		if err := fetch(imp.Path, imp.Version, dest); err != nil {
+			return nil, err
+		}
+		requires, err := readRequires(dest)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, requires...)
+	}
+
+	modules := make([]Module, 0, len(selected))
+	for path, version := range selected {
+		dest := Path(cacheDir, path, version)
+		requires, err := readRequires(dest)
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, Module{
+			Import:   Import{Path: path, Version: version, Mounts: mountsByPath[path]},
+			Dir:      dest,
+			Requires: requires,
+		})
+	}
+	return modules, nil
+}
+
+// higherVersion returns whichever of a, b is the higher semver.
+func higherVersion(a, b string) (string, error) {
+	va, err := semver.NewVersion(a)
+	if err != nil {
+		return "", err
+	}
+	vb, err := semver.NewVersion(b)
+	if err != nil {
+		return "", err
+	}
+	if va.Compare(vb) >= 0 {
+		return a, nil
+	}
+	return b, nil
+}
@@ -0,0 +1,34 @@
+package module
+
+import "testing"
+
+func TestHigherVersion(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want string
+	}{
+		{"v1.0.0", "v1.2.0", "v1.2.0"},
+		{"v1.2.0", "v1.0.0", "v1.2.0"},
+		{"v1.2.0", "v1.2.0", "v1.2.0"},
+		{"v2.0.0", "v1.9.9", "v2.0.0"},
+		{"v1.0.0-beta", "v1.0.0", "v1.0.0"},
+	}
+	for _, c := range cases {
+		got, err := higherVersion(c.a, c.b)
+		if err != nil {
+			t.Fatalf("higherVersion(%q, %q): %v", c.a, c.b, err)
+		}
+		if got != c.want {
+			t.Errorf("higherVersion(%q, %q) = %q; want %q", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestHigherVersionInvalid(t *testing.T) {
+	if _, err := higherVersion("not-a-version", "v1.0.0"); err == nil {
+		t.Error("higherVersion with an invalid version should error")
+	}
+	if _, err := higherVersion("v1.0.0", "not-a-version"); err == nil {
+		t.Error("higherVersion with an invalid version should error")
+	}
+}
@@ -0,0 +1,57 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Vendor copies every mounted directory of each module into
+// <vendorDir>/<module path>/<mount source>. Nothing in this package reads
+// vendorDir back; Resolve always works through the module cache.
+func Vendor(modules []Module, vendorDir string) error {
+	for _, m := range modules {
+		for _, mount := range m.Mounts {
+			src := filepath.Join(m.Dir, mount.Source)
+			if _, err := os.Stat(src); os.IsNotExist(err) {
+				continue
+			}
+			dst := filepath.Join(vendorDir, m.Path, mount.Source)
+			if err := copyTree(src, dst); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func copyTree(src, dst string) error {
+	if err := os.MkdirAll(dst, os.ModePerm); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+		if entry.IsDir() {
+			if err := copyTree(srcPath, dstPath); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := copyFile(srcPath, dstPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, os.ModePerm)
+}
@@ -0,0 +1,134 @@
+// Package server implements the HTTP server behind `vss serve`: it serves
+// the dist directory and live-reloads the browser over SSE whenever the
+// site is rebuilt.
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// reloadScript is injected before </body> in every served HTML page. It
+// opens an SSE connection and reloads the page on the first message.
+const reloadScript = `<script>
+(function() {
+	var es = new EventSource("/__vss/reload");
+	es.onmessage = function() { location.reload(); };
+})();
+</script>
+`
+
+// Server serves a dist directory and notifies connected browsers to
+// reload whenever Notify is called.
+type Server struct {
+	dir  string
+	addr string
+
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+// New returns a Server that serves dir on addr (e.g. "127.0.0.1:1313").
+func New(dir, addr string) *Server {
+	return &Server{
+		dir:     dir,
+		addr:    addr,
+		clients: make(map[chan struct{}]struct{}),
+	}
+}
+
+// Notify signals every connected browser to reload.
+func (s *Server) Notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ListenAndServe serves the site until ctx is cancelled.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/__vss/reload", s.handleReload)
+	mux.HandleFunc("/", s.handleContent)
+
+	httpServer := &http.Server{Addr: s.addr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		httpServer.Close()
+	}()
+
+	err := httpServer.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *Server) handleReload(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.clients[ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, ch)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) handleContent(w http.ResponseWriter, r *http.Request) {
+	path := filepath.Join(s.dir, filepath.Clean(r.URL.Path))
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "index.html")
+	}
+
+	if !strings.HasSuffix(path, ".html") {
+		http.ServeFile(w, r, path)
+		return
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if i := bytes.LastIndex(content, []byte("</body>")); i >= 0 {
+		content = append(content[:i], append([]byte(reloadScript), content[i:]...)...)
+	} else {
+		content = append(content, []byte(reloadScript)...)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(content)
+}
@@ -0,0 +1,141 @@
+package vss
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vssio/go-vss/internal/module"
+)
+
+// ModInit sets the site's own module path in its config, so that other
+// sites can import it as a module. This is the implementation behind
+// `vss mod init`.
+func ModInit(path string) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if config.Module == nil {
+		config.Module = &ModuleConfig{}
+	}
+	config.Module.Path = path
+	return SaveConfig(config)
+}
+
+// ModGet parses a "path@version" argument and adds or updates the
+// corresponding entry in the site's module imports, preserving any mounts
+// already configured for it. This is the implementation behind
+// `vss mod get`.
+func ModGet(pathVersion string) error {
+	path, version, ok := strings.Cut(pathVersion, "@")
+	if !ok {
+		return fmt.Errorf("expected <path@version>, got %q", pathVersion)
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if config.Module == nil {
+		config.Module = &ModuleConfig{}
+	}
+
+	imp := module.Import{Path: path, Version: version}
+	updated := false
+	for i, existing := range config.Module.Imports {
+		if existing.Path == path {
+			imp.Mounts = existing.Mounts
+			config.Module.Imports[i] = imp
+			updated = true
+			break
+		}
+	}
+	if !updated {
+		config.Module.Imports = append(config.Module.Imports, imp)
+	}
+
+	if _, err := module.Resolve(config.Module.Imports); err != nil {
+		return err
+	}
+	return SaveConfig(config)
+}
+
+// ModGraph resolves the site's module imports and renders the dependency
+// graph as one "path@version requires path@version" line per edge, like
+// `go mod graph`. This is the implementation behind `vss mod graph`.
+func ModGraph() (string, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	if config.Module == nil {
+		return "", nil
+	}
+
+	modules, err := module.Resolve(config.Module.Imports)
+	if err != nil {
+		return "", err
+	}
+
+	var graph strings.Builder
+	for _, m := range modules {
+		for _, req := range m.Requires {
+			fmt.Fprintf(&graph, "%s requires %s\n", m.Import, req)
+		}
+	}
+	return graph.String(), nil
+}
+
+// ModTidy re-resolves the site's module imports with minimal version
+// selection and rewrites the imports list to the versions actually
+// selected. This is the implementation behind `vss mod tidy`.
+func ModTidy() error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if config.Module == nil || len(config.Module.Imports) == 0 {
+		return nil
+	}
+
+	modules, err := module.Resolve(config.Module.Imports)
+	if err != nil {
+		return err
+	}
+
+	mountsByPath := make(map[string][]module.Mount, len(config.Module.Imports))
+	for _, imp := range config.Module.Imports {
+		mountsByPath[imp.Path] = imp.Mounts
+	}
+
+	imports := make([]module.Import, 0, len(config.Module.Imports))
+	for _, m := range modules {
+		mounts, ok := mountsByPath[m.Path]
+		if !ok {
+			continue // transitive requirement, not one of the site's own imports
+		}
+		imports = append(imports, module.Import{Path: m.Path, Version: m.Version, Mounts: mounts})
+	}
+	config.Module.Imports = imports
+	return SaveConfig(config)
+}
+
+// ModVendor resolves the site's module imports and copies their mounted
+// directories into ./vendor/modules. Builds do not read from it; they
+// resolve modules through the module cache. This is the implementation
+// behind `vss mod vendor`.
+func ModVendor() error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if config.Module == nil || len(config.Module.Imports) == 0 {
+		return nil
+	}
+
+	modules, err := module.Resolve(config.Module.Imports)
+	if err != nil {
+		return err
+	}
+	return module.Vendor(modules, "vendor/modules")
+}
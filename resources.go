@@ -0,0 +1,148 @@
+package vss
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cbroglie/mustache"
+
+	"github.com/vssio/go-vss/internal/imageproc"
+)
+
+// Resource describes one sibling file of a page bundle, as seen from a
+// mustache template via {{#resources}}.
+type Resource struct {
+	Name string // file name, relative to the bundle directory
+	Path string // public path, relative to the site root
+}
+
+// Resources exposes the sibling files of a page bundle to mustache
+// templates, along with the Resize/Fill/Fit derivative helpers.
+type Resources struct {
+	// bundleDir is the source directory holding the markdown file and its
+	// sibling resources, e.g. "posts/hello".
+	bundleDir string
+	// publicDir is bundleDir's location under dist, e.g. "posts/hello".
+	publicDir string
+	// genDir is where image derivatives are cached, relative to dist.
+	genDir   string
+	distRoot string
+}
+
+func newResources(bundleDir, publicDir, distRoot string) *Resources {
+	return &Resources{
+		bundleDir: bundleDir,
+		publicDir: publicDir,
+		genDir:    filepath.Join("resources", "_gen", "images"),
+		distRoot:  distRoot,
+	}
+}
+
+// List returns every non-markdown file alongside the page's markdown file.
+func (r *Resources) List() ([]Resource, error) {
+	entries, err := os.ReadDir(r.bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	var res []Resource
+	for _, e := range entries {
+		if e.IsDir() || strings.HasSuffix(e.Name(), ".md") {
+			continue
+		}
+		res = append(res, Resource{
+			Name: e.Name(),
+			Path: filepath.ToSlash(filepath.Join(r.publicDir, e.Name())),
+		})
+	}
+	return res, nil
+}
+
+// Resize scales name to fit within box ("WxH"), preserving aspect ratio,
+// and returns its public URL.
+func (r *Resources) Resize(name, box string) (string, error) {
+	return r.derive(name, imageproc.OpResize, box, "")
+}
+
+// Fill scales and crops name to exactly fill box ("WxH"), anchored per
+// anchor ("center", "top", "bottom", "left" or "right"), and returns its
+// public URL.
+func (r *Resources) Fill(name, box, anchor string) (string, error) {
+	return r.derive(name, imageproc.OpFill, box, anchor)
+}
+
+// Fit scales name down to fit within box ("WxH") without cropping, and
+// returns its public URL.
+func (r *Resources) Fit(name, box string) (string, error) {
+	return r.derive(name, imageproc.OpFit, box, "")
+}
+
+func (r *Resources) derive(name string, op imageproc.Op, box, anchor string) (string, error) {
+	w, h, err := imageproc.ParseBox(box)
+	if err != nil {
+		return "", err
+	}
+	if anchor == "" {
+		anchor = string(imageproc.AnchorCenter)
+	}
+
+	srcPath := filepath.Join(r.bundleDir, name)
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return "", fmt.Errorf("vss: resource %q not found in %s: %w", name, r.bundleDir, err)
+	}
+
+	spec := imageproc.Spec{Op: op, Width: w, Height: h, Anchor: imageproc.Anchor(anchor)}
+	cachePath := imageproc.CachePath(srcPath, info.ModTime().UnixNano(), spec)
+	dstPath := filepath.Join(r.distRoot, cachePath)
+	if err := imageproc.Process(srcPath, dstPath, spec); err != nil {
+		return "", err
+	}
+	return filepath.ToSlash("/" + cachePath), nil
+}
+
+// mustacheFuncs returns the template functions backing {{#resize}},
+// {{#fill}} and {{#fit}}. Each is a mustache.LambdaFunc: the section's
+// raw text is the call's arguments, space separated, e.g.
+// "cover.jpg 600x400 center".
+func (r *Resources) mustacheFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		"resize": mustache.LambdaFunc(func(text string, render mustache.RenderFunc) (string, error) {
+			return r.callLambda(text, r.Resize)
+		}),
+		"fit": mustache.LambdaFunc(func(text string, render mustache.RenderFunc) (string, error) {
+			return r.callLambda(text, r.Fit)
+		}),
+		"fill": mustache.LambdaFunc(func(text string, render mustache.RenderFunc) (string, error) {
+			args := strings.Fields(text)
+			if len(args) < 2 {
+				return "", nil
+			}
+			anchor := ""
+			if len(args) > 2 {
+				anchor = args[2]
+			}
+			url, err := r.Fill(args[0], args[1], anchor)
+			if err != nil {
+				return "", nil
+			}
+			return url, nil
+		}),
+	}
+}
+
+// callLambda parses a "name box" section body and dispatches to fn,
+// returning the derivative URL, or "" if the body is malformed or the
+// resource is missing (dropping the tag rather than aborting the render).
+func (r *Resources) callLambda(text string, fn func(name, box string) (string, error)) (string, error) {
+	args := strings.Fields(text)
+	if len(args) < 2 {
+		return "", nil
+	}
+	url, err := fn(args[0], args[1])
+	if err != nil {
+		return "", nil
+	}
+	return url, nil
+}
@@ -0,0 +1,59 @@
+package vss
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cbroglie/mustache"
+)
+
+func writeTestBundleImage(t *testing.T, path string, w, h int) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0, A: 0xff})
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create %s: %v", path, err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("encode %s: %v", path, err)
+	}
+}
+
+// TestResourcesMustacheFuncsRenderThroughSections renders actual mustache
+// templates through the {{#resize}}, {{#fit}} and {{#fill}} sections, the
+// same call path Builder.Run uses. mustache only accepts section lambdas
+// matching its 2-in/2-out LambdaFunc signature; this exercises that path
+// directly rather than just calling the funcs as plain Go functions.
+func TestResourcesMustacheFuncsRenderThroughSections(t *testing.T) {
+	dir := t.TempDir()
+	writeTestBundleImage(t, filepath.Join(dir, "cover.jpg"), 400, 200)
+
+	distRoot := t.TempDir()
+	r := newResources(dir, "posts/hello", distRoot)
+
+	tmpl := `<img src="{{#fill}}cover.jpg 100x100 center{{/fill}}">` +
+		`<img src="{{#resize}}cover.jpg 200x100{{/resize}}">` +
+		`<img src="{{#fit}}cover.jpg 200x200{{/fit}}">`
+
+	out, err := mustache.Render(tmpl, r.mustacheFuncs())
+	if err != nil {
+		t.Fatalf("mustache.Render: %v", err)
+	}
+	if out == tmpl {
+		t.Fatalf("sections did not expand at all: %q", out)
+	}
+	if want, got := 3, strings.Count(out, `src="/resources/_gen/images/`); got != want {
+		t.Fatalf("expected %d rendered derivative URLs, got %d in %q", want, got, out)
+	}
+}
@@ -0,0 +1,70 @@
+package vss
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/vssio/go-vss/internal/server"
+)
+
+// Serve builds the site, serves dist over HTTP on addr, and live-reloads
+// the browser whenever content, layouts or static files change. It blocks
+// until ctx is cancelled. This is the implementation behind `vss serve`.
+func Serve(ctx context.Context, addr string) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	builder := NewBuilder(config)
+	if err := builder.Run(); err != nil {
+		return err
+	}
+
+	srv := server.New(builder.GetDistPath(), addr)
+
+	go func() {
+		err := builder.Watch(ctx, func(paths []string) error {
+			if needsFullRebuild(builder, paths) {
+				log.Printf("[INFO] rebuilding site")
+				if err := builder.Run(); err != nil {
+					return err
+				}
+			} else {
+				for _, path := range paths {
+					if !strings.HasSuffix(path, ".md") {
+						continue
+					}
+					log.Printf("[INFO] rebuilding %s", path)
+					if err := builder.RenderFile(path); err != nil {
+						return err
+					}
+				}
+			}
+			srv.Notify()
+			return nil
+		})
+		if err != nil {
+			log.Printf("[ERROR] watch: %s", err)
+		}
+	}()
+
+	return srv.ListenAndServe(ctx)
+}
+
+// needsFullRebuild reports whether any changed path is not a markdown file
+// (a layout, static file or config change) or is a markdown file under
+// layouts, either of which can affect every page rather than just one.
+func needsFullRebuild(builder *Builder, paths []string) bool {
+	layouts := builder.GetConfig().Layouts
+	for _, path := range paths {
+		if !strings.HasSuffix(path, ".md") {
+			return true
+		}
+		if strings.HasPrefix(path, layouts+string(os.PathSeparator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package vss
 
 import (
 	"log"
+	"os"
 
 	"github.com/mitchellh/cli"
 	"github.com/vssio/go-vss/internal/command"
@@ -16,7 +17,12 @@ func Version() string {
 }
 
 func Run(args []string) int {
-	metaPtr := new(command.Meta)
+	metaPtr := &command.Meta{
+		UI: &cli.BasicUi{
+			Writer:      os.Stdout,
+			ErrorWriter: os.Stderr,
+		},
+	}
 	c := &cli.CLI{
 		Name:         "vss",
 		Version:      version,
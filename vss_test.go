@@ -0,0 +1,35 @@
+package vss
+
+import (
+	"os"
+	"testing"
+)
+
+// TestRunDoesNotPanicOnUICalls exercises both the error and (via a
+// trivial config) success path of a command's UI output. Run used to
+// build command.Meta without a Ui, so c.UI.Info/c.UI.Error panicked on a
+// nil interface the moment any command tried to report anything.
+func TestRunDoesNotPanicOnUICalls(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	defer os.Chdir(wd)
+
+	// No vss.toml exists, so this exercises the UI.Error path.
+	if code := Run([]string{"mod", "init", "example.com/theme"}); code == 0 {
+		t.Fatalf("Run(mod init) with no vss.toml = %d; want non-zero", code)
+	}
+
+	// Now exercise the UI.Info success path.
+	if err := os.WriteFile("vss.toml", []byte(""), 0o644); err != nil {
+		t.Fatalf("write vss.toml: %v", err)
+	}
+	if code := Run([]string{"mod", "init", "example.com/theme"}); code != 0 {
+		t.Fatalf("Run(mod init) = %d; want 0", code)
+	}
+}
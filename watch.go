@@ -0,0 +1,147 @@
+package vss
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long Watch waits after the last event in a burst
+// before invoking the rebuild callback.
+const watchDebounce = 100 * time.Millisecond
+
+// RebuildFunc is invoked by Watch once a burst of filesystem events has
+// settled. paths is the set of files that changed during the burst, so the
+// caller can choose between a single-file and a full rebuild.
+type RebuildFunc func(paths []string) error
+
+// Watch walks the project's content, layouts and static directories,
+// watches them for changes with fsnotify, and calls rebuild after
+// debouncing each burst of events over watchDebounce. It blocks until ctx
+// is cancelled.
+func (b *Builder) Watch(ctx context.Context, rebuild RebuildFunc) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	excluded := watchExclusions(b.config)
+	for _, dir := range []string{".", b.config.Layouts, b.config.Static} {
+		if err := addRecursive(watcher, dir, excluded); err != nil {
+			return err
+		}
+	}
+
+	var timer *time.Timer
+	pending := make(map[string]struct{})
+	fire := make(chan struct{}, 1)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if isExcluded(event.Name, excluded) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addRecursive(watcher, event.Name, excluded); err != nil {
+						log.Printf("[ERROR] watch: %s", err)
+					}
+				}
+			}
+			if event.Has(fsnotify.Remove) {
+				_ = watcher.Remove(event.Name)
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+			pending[event.Name] = struct{}{}
+			if timer == nil {
+				timer = time.AfterFunc(watchDebounce, func() { fire <- struct{}{} })
+			} else {
+				timer.Reset(watchDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("[ERROR] watch: %s", err)
+
+		case <-fire:
+			if len(pending) == 0 {
+				continue
+			}
+			paths := make([]string, 0, len(pending))
+			for p := range pending {
+				paths = append(paths, p)
+				delete(pending, p)
+			}
+			if err := rebuild(paths); err != nil {
+				log.Printf("[ERROR] rebuild: %s", err)
+			}
+		}
+	}
+}
+
+// addRecursive adds root and every directory beneath it to watcher,
+// except the paths in excluded (and anything beneath them). It is a
+// no-op if root does not exist, since content/layouts/static are
+// optional.
+func addRecursive(watcher *fsnotify.Watcher, root string, excluded map[string]bool) error {
+	if !existDir(root) {
+		return nil
+	}
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if isExcluded(path, excluded) {
+				return filepath.SkipDir
+			}
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// watchExclusions returns the set of directories Watch must not watch:
+// config.Dist and cacheDir. Both are written to by the builder's own
+// rebuilds, so watching them would make every rebuild trigger another.
+func watchExclusions(config *Config) map[string]bool {
+	excluded := map[string]bool{
+		filepath.Clean(cacheDir): true,
+	}
+	if config.Dist != "" {
+		excluded[filepath.Clean(config.Dist)] = true
+	}
+	return excluded
+}
+
+// isExcluded reports whether path is one of excluded's directories, or
+// lies beneath one of them.
+func isExcluded(path string, excluded map[string]bool) bool {
+	dir := filepath.Clean(path)
+	for {
+		if excluded[dir] {
+			return true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
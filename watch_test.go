@@ -0,0 +1,82 @@
+package vss
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestIsExcluded(t *testing.T) {
+	excluded := map[string]bool{"dist": true, ".vss-cache": true}
+
+	cases := []struct {
+		path string
+		want bool
+	}{
+		{"dist", true},
+		{"dist/index.html", true},
+		{"dist/posts/hello.html", true},
+		{".vss-cache/manifest.json", true},
+		{"content/index.md", false},
+		{"layouts/base.mustache", false},
+		{".", false},
+	}
+	for _, c := range cases {
+		if got := isExcluded(c.path, excluded); got != c.want {
+			t.Errorf("isExcluded(%q) = %v; want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestWatchExclusions(t *testing.T) {
+	excluded := watchExclusions(&Config{Dist: "dist"})
+	if !excluded[cacheDir] {
+		t.Errorf("watchExclusions did not exclude cacheDir %q", cacheDir)
+	}
+	if !excluded["dist"] {
+		t.Errorf("watchExclusions did not exclude config.Dist %q", "dist")
+	}
+}
+
+// TestAddRecursiveSkipsExcludedDirs confirms a dist/ directory under the
+// watched root is never added to the watcher, so the builder's own
+// writes into it don't generate events that trigger another rebuild.
+func TestAddRecursiveSkipsExcludedDirs(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"content", "dist", filepath.Join("dist", "posts"), cacheDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), os.ModePerm); err != nil {
+			t.Fatalf("mkdir %s: %v", sub, err)
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+	defer watcher.Close()
+
+	excluded := map[string]bool{
+		filepath.Join(dir, "dist"):   true,
+		filepath.Join(dir, cacheDir): true,
+	}
+	if err := addRecursive(watcher, dir, excluded); err != nil {
+		t.Fatalf("addRecursive: %v", err)
+	}
+
+	watched := watcher.WatchList()
+	for _, p := range watched {
+		if isExcluded(p, excluded) {
+			t.Errorf("watcher is watching excluded path %q", p)
+		}
+	}
+
+	watchedSet := make(map[string]bool, len(watched))
+	for _, p := range watched {
+		watchedSet[p] = true
+	}
+	if !watchedSet[filepath.Join(dir, "content")] {
+		t.Errorf("watcher should still watch content/, got %v", watched)
+	}
+}